@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// GUIDRecord is a snapshot of one item's identity-bearing fields, used for
+// checks that need to compare items across files or across time: cross-file
+// duplicate detection and the -baseline GUID-stability check.
+type GUIDRecord struct {
+	File    string `json:"file"`
+	Feed    string `json:"feed"`
+	Item    string `json:"item"`
+	GUID    string `json:"guid"`
+	Link    string `json:"link"`
+	PubDate string `json:"pubDate"`
+}
+
+// guidRecords extracts a GUIDRecord for every item that has a GUID.
+func guidRecords(r RSS) []GUIDRecord {
+	var records []GUIDRecord
+	for _, item := range r.Channel.Items {
+		if item.GUID.Value == "" {
+			continue
+		}
+		records = append(records, GUIDRecord{
+			Feed:    r.Channel.Title,
+			Item:    item.Title,
+			GUID:    item.GUID.Value,
+			Link:    item.Link,
+			PubDate: item.PubDate,
+		})
+	}
+	return records
+}
+
+// crossFileGUIDIssues flags a GUID that appears in more than one feed file,
+// which breaks aggregators that key on GUID alone.
+func crossFileGUIDIssues(records []GUIDRecord) []ValidationIssue {
+	filesByGUID := map[string]map[string]bool{}
+	for _, rec := range records {
+		if filesByGUID[rec.GUID] == nil {
+			filesByGUID[rec.GUID] = map[string]bool{}
+		}
+		filesByGUID[rec.GUID][rec.File] = true
+	}
+
+	var issues []ValidationIssue
+	for _, rec := range records {
+		files := filesByGUID[rec.GUID]
+		if len(files) < 2 {
+			continue
+		}
+		var others []string
+		for f := range files {
+			if f != rec.File {
+				others = append(others, f)
+			}
+		}
+		sort.Strings(others)
+		issues = append(issues, ValidationIssue{
+			File:     rec.File,
+			Feed:     rec.Feed,
+			Item:     rec.Item,
+			Rule:     "cross-file-guid",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("GUID '%s' also appears in: %s", rec.GUID, strings.Join(others, ", ")),
+		})
+	}
+	return issues
+}
+
+// baselineGUIDIssues compares the current run's records against a baseline
+// snapshot and flags any GUID whose link or pubDate changed, since readers
+// like Miniflux key on GUID and re-notify subscribers when those drift.
+func baselineGUIDIssues(current []GUIDRecord, baseline map[string]GUIDRecord) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, rec := range current {
+		prev, ok := baseline[rec.GUID]
+		if !ok {
+			continue
+		}
+		if prev.Link != rec.Link || prev.PubDate != rec.PubDate {
+			issues = append(issues, ValidationIssue{
+				File:     rec.File,
+				Feed:     rec.Feed,
+				Item:     rec.Item,
+				Rule:     "guid-stability",
+				Severity: SeverityError,
+				Message: fmt.Sprintf("GUID '%s' changed link/pubDate since baseline (was link=%q pubDate=%q, now link=%q pubDate=%q)",
+					rec.GUID, prev.Link, prev.PubDate, rec.Link, rec.PubDate),
+			})
+		}
+	}
+	return issues
+}
+
+// loadBaseline resolves -baseline to a map of GUID -> GUIDRecord, accepting
+// either a path to a JSON report produced by a previous -format=json run, or
+// a git ref whose ./feed tree is read via `git show`.
+func loadBaseline(path string) (map[string]GUIDRecord, error) {
+	var records []GUIDRecord
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading baseline report: %v", err)
+		}
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("parsing baseline report: %v", err)
+		}
+		records = report.Records
+	} else {
+		records, err = guidRecordsAtGitRef(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byGUID := make(map[string]GUIDRecord, len(records))
+	for _, rec := range records {
+		byGUID[rec.GUID] = rec
+	}
+	return byGUID, nil
+}
+
+// guidRecordsAtGitRef reads every feed file as it existed at the given git
+// ref and extracts its GUID records, without running any validation rules.
+func guidRecordsAtGitRef(ref string) ([]GUIDRecord, error) {
+	out, err := exec.Command("git", "ls-tree", "-r", "--name-only", ref, "--", "feed").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing feed files at %s: %v", ref, err)
+	}
+
+	var records []GUIDRecord
+	for _, path := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if path == "" || !(strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".rss")) {
+			continue
+		}
+		data, err := exec.Command("git", "show", ref+":"+path).Output()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s at %s: %v", path, ref, err)
+		}
+		rss, err := parseAsRSS(data)
+		if err != nil {
+			continue
+		}
+		for _, rec := range guidRecords(rss) {
+			rec.File = path
+			records = append(records, rec)
+		}
+	}
+	return records, nil
+}
+
+// parseAsRSS unmarshals plain RSS and podcast feeds into a common RSS value
+// for record extraction. Atom feeds have no RSS-style GUID and are skipped.
+func parseAsRSS(data []byte) (RSS, error) {
+	root, err := sniffRootElement(data)
+	if err != nil || root.Name.Local != "rss" {
+		return RSS{}, fmt.Errorf("not an RSS feed")
+	}
+	if isPodcastFeed(root) {
+		var podcast PodcastRSS
+		if err := xml.Unmarshal(data, &podcast); err != nil {
+			return RSS{}, err
+		}
+		return podcast.asRSS(), nil
+	}
+	var rss RSS
+	if err := xml.Unmarshal(data, &rss); err != nil {
+		return RSS{}, err
+	}
+	return rss, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestCrossFileGUIDIssues(t *testing.T) {
+	records := []GUIDRecord{
+		{File: "a.xml", Feed: "Feed A", Item: "one", GUID: "dup-1"},
+		{File: "b.xml", Feed: "Feed B", Item: "two", GUID: "dup-1"},
+		{File: "a.xml", Feed: "Feed A", Item: "three", GUID: "unique"},
+	}
+
+	issues := crossFileGUIDIssues(records)
+
+	if len(issues) != 2 {
+		t.Fatalf("crossFileGUIDIssues() returned %d issues, want 2 (one per file sharing the duplicate GUID): %+v", len(issues), issues)
+	}
+	for _, issue := range issues {
+		if issue.Rule != "cross-file-guid" {
+			t.Errorf("issue.Rule = %q, want %q", issue.Rule, "cross-file-guid")
+		}
+	}
+}
+
+func TestCrossFileGUIDIssuesNoDuplicates(t *testing.T) {
+	records := []GUIDRecord{
+		{File: "a.xml", GUID: "one"},
+		{File: "b.xml", GUID: "two"},
+	}
+
+	if issues := crossFileGUIDIssues(records); len(issues) != 0 {
+		t.Errorf("crossFileGUIDIssues() = %+v, want no issues", issues)
+	}
+}
+
+func TestBaselineGUIDIssues(t *testing.T) {
+	baseline := map[string]GUIDRecord{
+		"stable":  {GUID: "stable", Link: "https://example.com/1", PubDate: "Mon, 02 Jan 2006 15:04:05 -0700"},
+		"changed": {GUID: "changed", Link: "https://example.com/2", PubDate: "Mon, 02 Jan 2006 15:04:05 -0700"},
+	}
+	current := []GUIDRecord{
+		{File: "feed.xml", GUID: "stable", Link: "https://example.com/1", PubDate: "Mon, 02 Jan 2006 15:04:05 -0700"},
+		{File: "feed.xml", GUID: "changed", Link: "https://example.com/2-moved", PubDate: "Mon, 02 Jan 2006 15:04:05 -0700"},
+		{File: "feed.xml", GUID: "new", Link: "https://example.com/3", PubDate: "Mon, 02 Jan 2006 15:04:05 -0700"},
+	}
+
+	issues := baselineGUIDIssues(current, baseline)
+
+	if len(issues) != 1 {
+		t.Fatalf("baselineGUIDIssues() returned %d issues, want 1: %+v", len(issues), issues)
+	}
+	if issues[0].Rule != "guid-stability" {
+		t.Errorf("issue.Rule = %q, want %q", issues[0].Rule, "guid-stability")
+	}
+}
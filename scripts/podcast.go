@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PodcastRSS is an RSS 2.0 feed carrying the iTunes podcast namespace
+// extension. It embeds RSS so a podcast feed still satisfies the base
+// RSS 2.0 rules, with the stricter enclosure/itunes checks layered on top.
+type PodcastRSS struct {
+	RSS
+	Channel PodcastChannel `xml:"channel"`
+}
+
+type PodcastChannel struct {
+	Channel
+	ITunesAuthor   string           `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ITunesSummary  string           `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ITunesImage    *ITunesImage     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ITunesCategory []ITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+	PodcastItems   []PodcastItem    `xml:"item"`
+}
+
+type PodcastItem struct {
+	Item
+	ITunesAuthor   string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	ITunesSummary  string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ITunesImage    *ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ITunesExplicit string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
+	ITunesDuration string       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+}
+
+type ITunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type ITunesCategory struct {
+	Text string `xml:"text,attr"`
+}
+
+// allowedEnclosureTypes are the MIME types podcast players reliably support.
+var allowedEnclosureTypes = map[string]bool{
+	"audio/mpeg":  true,
+	"audio/mp4":   true,
+	"audio/x-m4a": true,
+	"audio/ogg":   true,
+	"video/mp4":   true,
+}
+
+var itunesDurationRE = regexp.MustCompile(`^(?:(\d+):)?([0-5]?\d):([0-5]\d)$`)
+
+// Validate runs the base RSS 2.0 checks against the embedded channel and
+// items, then the podcast-specific enclosure and itunes:duration rules.
+func (p *PodcastRSS) Validate() []ValidationIssue {
+	rss := p.asRSS()
+	issues := rss.Validate()
+	issues = append(issues, p.validateEnclosures()...)
+	return issues
+}
+
+// asRSS flattens the podcast-specific item wrappers back into plain Items
+// so the base RSS.Validate rules (required fields, dates, GUIDs) run
+// against the real channel and item data.
+func (p *PodcastRSS) asRSS() RSS {
+	channel := p.Channel.Channel
+	channel.Items = make([]Item, len(p.Channel.PodcastItems))
+	for i, item := range p.Channel.PodcastItems {
+		channel.Items[i] = item.Item
+	}
+	return RSS{XMLName: p.XMLName, Channel: channel}
+}
+
+func (p *PodcastRSS) validateEnclosures() []ValidationIssue {
+	var issues []ValidationIssue
+	for _, item := range p.Channel.PodcastItems {
+		for _, problem := range validatePodcastItem(item) {
+			issues = append(issues, ValidationIssue{
+				Feed:     p.Channel.Title,
+				Item:     item.Title,
+				Rule:     "podcast-enclosure",
+				Severity: SeverityError,
+				Message:  problem,
+			})
+		}
+	}
+	return issues
+}
+
+// validatePodcastItem checks every podcast-specific requirement on an item
+// and returns all problems found, rather than stopping at the first one, so
+// an item with several defects gets reported in full.
+func validatePodcastItem(item PodcastItem) []string {
+	var problems []string
+
+	if len(item.Enclosures) != 1 {
+		problems = append(problems, fmt.Sprintf("expected exactly one enclosure, found %d", len(item.Enclosures)))
+	} else {
+		enclosure := item.Enclosures[0]
+
+		if u, err := url.Parse(enclosure.URL); err != nil || !u.IsAbs() {
+			problems = append(problems, fmt.Sprintf("enclosure url '%s' is not an absolute URL", enclosure.URL))
+		}
+
+		if !allowedEnclosureTypes[enclosure.Type] {
+			problems = append(problems, fmt.Sprintf("enclosure type '%s' is not an allowed podcast MIME type", enclosure.Type))
+		}
+
+		if length, err := strconv.Atoi(enclosure.Length); err != nil || length <= 0 {
+			problems = append(problems, fmt.Sprintf("enclosure length '%s' must be a positive number of bytes", enclosure.Length))
+		}
+	}
+
+	if _, err := parseItunesDuration(item.ITunesDuration); err != nil {
+		problems = append(problems, fmt.Sprintf("itunes:duration: %v", err))
+	}
+
+	if item.GUID.Value == "" {
+		problems = append(problems, "missing guid")
+	}
+
+	return problems
+}
+
+// parseItunesDuration accepts the three formats podcast hosts emit for
+// itunes:duration: HH:MM:SS, MM:SS, or a bare number of seconds.
+func parseItunesDuration(str string) (time.Duration, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return 0, fmt.Errorf("missing duration")
+	}
+
+	if seconds, err := strconv.Atoi(str); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("duration '%s' must not be negative", str)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	match := itunesDurationRE.FindStringSubmatch(str)
+	if match == nil {
+		return 0, fmt.Errorf("duration '%s' is not HH:MM:SS, MM:SS, or a number of seconds", str)
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	total := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	return total, nil
+}
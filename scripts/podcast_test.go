@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseItunesDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int // seconds
+		wantErr bool
+	}{
+		{"hh:mm:ss", "1:02:03", 3723, false},
+		{"mm:ss", "4:13", 253, false},
+		{"seconds", "253", 253, false},
+		{"padded mm:ss", "04:13", 253, false},
+		{"zero seconds", "0", 0, false},
+		{"negative seconds", "-5", 0, true},
+		{"invalid minutes", "9:99", 0, true},
+		{"empty", "", 0, true},
+		{"garbage", "not a duration", 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseItunesDuration(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseItunesDuration(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+			if err == nil && int(got.Seconds()) != tc.want {
+				t.Errorf("parseItunesDuration(%q) = %v, want %ds", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidatePodcastItemAccumulatesAllProblems(t *testing.T) {
+	item := PodcastItem{
+		Item: Item{
+			Title: "episode",
+			GUID:  GUID{Value: ""},
+			Enclosures: []Enclosure{
+				{URL: "not-absolute.mp3", Type: "audio/mpeg", Length: "12345"},
+			},
+		},
+		ITunesDuration: "not-a-duration",
+	}
+
+	problems := validatePodcastItem(item)
+
+	wantSubstrings := []string{"absolute URL", "itunes:duration", "missing guid"}
+	if len(problems) != len(wantSubstrings) {
+		t.Fatalf("validatePodcastItem() = %v, want %d problems", problems, len(wantSubstrings))
+	}
+	for _, want := range wantSubstrings {
+		found := false
+		for _, problem := range problems {
+			if strings.Contains(problem, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("validatePodcastItem() = %v, want a problem containing %q", problems, want)
+		}
+	}
+}
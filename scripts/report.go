@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Severity classifies a ValidationIssue: Error issues always fail the
+// build, Warning issues only fail it when -strict is set.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue is one rule failure or warning against one feed file. It
+// is the unit every output format (text, json, junit, sarif) is built from.
+type ValidationIssue struct {
+	File     string   `json:"file"`
+	Feed     string   `json:"feed,omitempty"`
+	Item     string   `json:"item,omitempty"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func errorIssue(rule, message string) ValidationIssue {
+	return ValidationIssue{Rule: rule, Severity: SeverityError, Message: message}
+}
+
+func warningIssue(rule, message string) ValidationIssue {
+	return ValidationIssue{Rule: rule, Severity: SeverityWarning, Message: message}
+}
+
+// Report is the result of a full run of verify_rss across every feed file.
+// Records is carried in the JSON output (but not the other formats) so a
+// later run can pass this report back in via -baseline to catch GUID churn.
+type Report struct {
+	FilesChecked int               `json:"filesChecked"`
+	Files        []string          `json:"files,omitempty"`
+	Issues       []ValidationIssue `json:"issues"`
+	Records      []GUIDRecord      `json:"records,omitempty"`
+}
+
+func (r *Report) errorCount() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *Report) warningCount() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityWarning {
+			count++
+		}
+	}
+	return count
+}
+
+// renderReport formats a report in the requested output format.
+func renderReport(report Report, format string) (string, error) {
+	switch format {
+	case "text":
+		return renderText(report), nil
+	case "json":
+		return renderJSON(report)
+	case "junit":
+		return renderJUnit(report)
+	case "sarif":
+		return renderSARIF(report)
+	default:
+		return "", fmt.Errorf("unknown -format %q (want text, json, junit, or sarif)", format)
+	}
+}
+
+func renderText(report Report) string {
+	var b strings.Builder
+	for _, issue := range report.Issues {
+		loc := issue.File
+		if issue.Feed != "" {
+			loc += fmt.Sprintf(" [%s]", issue.Feed)
+		}
+		if issue.Item != "" {
+			loc += fmt.Sprintf(" item '%s'", issue.Item)
+		}
+		fmt.Fprintf(&b, "%s: %s: %s: %s\n", strings.ToUpper(string(issue.Severity)), loc, issue.Rule, issue.Message)
+	}
+	fmt.Fprintf(&b, "\n%d file(s) checked, %d error(s), %d warning(s)\n", report.FilesChecked, report.errorCount(), report.warningCount())
+	return b.String()
+}
+
+func renderJSON(report Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling JSON report: %v", err)
+	}
+	return string(data), nil
+}
+
+type junitTestsuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Testcases []junitTestcase `xml:"testcase"`
+}
+
+type junitTestcase struct {
+	Name      string         `xml:"name,attr"`
+	Failures  []junitMessage `xml:"failure"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnit emits one <testcase> per checked file, so the suite's `tests`
+// attribute always matches its <testcase> children: errors become <failure>
+// elements (so CI marks the file failed), warnings are appended to
+// <system-out> so they're visible without failing the suite. A clean file
+// still gets a <testcase> with no children, which is what makes it show up
+// as a passing test.
+func renderJUnit(report Report) (string, error) {
+	byFile := map[string][]ValidationIssue{}
+	for _, issue := range report.Issues {
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+
+	suite := junitTestsuite{Name: "verify_rss", Tests: len(report.Files)}
+	for _, file := range report.Files {
+		tc := junitTestcase{Name: file}
+		var warnings []string
+		for _, issue := range byFile[file] {
+			if issue.Severity == SeverityError {
+				tc.Failures = append(tc.Failures, junitMessage{Message: issue.Rule, Text: issue.Message})
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", issue.Rule, issue.Message))
+			}
+		}
+		tc.SystemOut = strings.Join(warnings, "\n")
+		suite.Failures += len(tc.Failures)
+		suite.Testcases = append(suite.Testcases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling JUnit report: %v", err)
+	}
+	return xml.Header + string(data), nil
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func renderSARIF(report Report) (string, error) {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{Name: "verify_rss"}},
+		}},
+	}
+	for _, issue := range report.Issues {
+		level := "warning"
+		if issue.Severity == SeverityError {
+			level = "error"
+		}
+		log.Runs[0].Results = append(log.Runs[0].Results, sarifResult{
+			RuleID:  issue.Rule,
+			Level:   level,
+			Message: sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: issue.File},
+				},
+			}},
+		})
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling SARIF report: %v", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sampleReport() Report {
+	return Report{
+		FilesChecked: 2,
+		Files:        []string{"clean.xml", "bad.xml"},
+		Issues: []ValidationIssue{
+			{File: "bad.xml", Feed: "Feed", Item: "Item", Rule: "missing-title", Severity: SeverityError, Message: "channel is missing <title>"},
+			{File: "bad.xml", Feed: "Feed", Item: "Item", Rule: "date-order", Severity: SeverityWarning, Message: "items are out of chronological order"},
+		},
+	}
+}
+
+func TestRenderJSONRoundTrips(t *testing.T) {
+	report := sampleReport()
+
+	out, err := renderJSON(report)
+	if err != nil {
+		t.Fatalf("renderJSON() error = %v", err)
+	}
+
+	var got Report
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("renderJSON() produced invalid JSON: %v", err)
+	}
+	if len(got.Issues) != len(report.Issues) {
+		t.Errorf("round-tripped report has %d issues, want %d", len(got.Issues), len(report.Issues))
+	}
+	if got.FilesChecked != report.FilesChecked {
+		t.Errorf("round-tripped FilesChecked = %d, want %d", got.FilesChecked, report.FilesChecked)
+	}
+}
+
+func TestRenderJUnitTestcaseCountMatchesTestsAttribute(t *testing.T) {
+	report := sampleReport()
+
+	out, err := renderJUnit(report)
+	if err != nil {
+		t.Fatalf("renderJUnit() error = %v", err)
+	}
+
+	var suite junitTestsuite
+	if err := xml.Unmarshal([]byte(out), &suite); err != nil {
+		t.Fatalf("renderJUnit() produced invalid XML: %v", err)
+	}
+	if suite.Tests != len(report.Files) {
+		t.Errorf("suite.Tests = %d, want %d (one per checked file)", suite.Tests, len(report.Files))
+	}
+	if len(suite.Testcases) != suite.Tests {
+		t.Fatalf("suite has %d <testcase> children, want %d to match suite.Tests", len(suite.Testcases), suite.Tests)
+	}
+	if suite.Failures != 1 {
+		t.Errorf("suite.Failures = %d, want 1", suite.Failures)
+	}
+}
+
+func TestRenderJUnitCleanFileGetsPassingTestcase(t *testing.T) {
+	report := sampleReport()
+
+	out, err := renderJUnit(report)
+	if err != nil {
+		t.Fatalf("renderJUnit() error = %v", err)
+	}
+
+	var suite junitTestsuite
+	if err := xml.Unmarshal([]byte(out), &suite); err != nil {
+		t.Fatalf("renderJUnit() produced invalid XML: %v", err)
+	}
+
+	var clean *junitTestcase
+	for i := range suite.Testcases {
+		if suite.Testcases[i].Name == "clean.xml" {
+			clean = &suite.Testcases[i]
+		}
+	}
+	if clean == nil {
+		t.Fatal("no <testcase> for clean.xml, which was checked but has no issues")
+	}
+	if len(clean.Failures) != 0 {
+		t.Errorf("clean.xml testcase has %d <failure> children, want 0", len(clean.Failures))
+	}
+}
+
+func TestRenderSARIFIncludesEachIssue(t *testing.T) {
+	report := sampleReport()
+
+	out, err := renderSARIF(report)
+	if err != nil {
+		t.Fatalf("renderSARIF() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("renderSARIF() produced invalid JSON: %v", err)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("log has %d runs, want 1", len(log.Runs))
+	}
+	if len(log.Runs[0].Results) != len(report.Issues) {
+		t.Errorf("log has %d results, want %d", len(log.Runs[0].Results), len(report.Issues))
+	}
+	if log.Runs[0].Results[0].Level != "error" {
+		t.Errorf("error-severity issue rendered at level %q, want %q", log.Runs[0].Results[0].Level, "error")
+	}
+	if log.Runs[0].Results[1].Level != "warning" {
+		t.Errorf("warning-severity issue rendered at level %q, want %q", log.Runs[0].Results[1].Level, "warning")
+	}
+}
+
+func TestErrorCountAndWarningCount(t *testing.T) {
+	report := sampleReport()
+
+	if got := report.errorCount(); got != 1 {
+		t.Errorf("errorCount() = %d, want 1", got)
+	}
+	if got := report.warningCount(); got != 1 {
+		t.Errorf("warningCount() = %d, want 1", got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		report      Report
+		parseFailed bool
+		strict      bool
+		want        int
+	}{
+		{"parse failure wins regardless of issues", sampleReport(), true, false, 3},
+		{"errors present", sampleReport(), false, false, 2},
+		{"warnings only, not strict", Report{Issues: []ValidationIssue{{Severity: SeverityWarning}}}, false, false, 0},
+		{"warnings only, strict", Report{Issues: []ValidationIssue{{Severity: SeverityWarning}}}, false, true, 1},
+		{"clean", Report{}, false, false, 0},
+		{"clean, strict", Report{}, false, true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := exitCode(tc.report, tc.parseFailed, tc.strict); got != tc.want {
+				t.Errorf("exitCode() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderTextSummarizesCounts(t *testing.T) {
+	report := sampleReport()
+
+	out := renderText(report)
+
+	if !strings.Contains(out, "2 file(s) checked, 1 error(s), 1 warning(s)") {
+		t.Errorf("renderText() = %q, want it to contain the file/error/warning summary", out)
+	}
+}
+
+func TestRenderReportUnknownFormat(t *testing.T) {
+	if _, err := renderReport(sampleReport(), "yaml"); err == nil {
+		t.Fatal("renderReport() with an unknown format should return an error")
+	}
+}
@@ -2,56 +2,178 @@ package main
 
 import (
 	"encoding/xml"
-	"errors"
+	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 )
 
+var (
+	format   = flag.String("format", "text", "output format: text, json, junit, or sarif")
+	strict   = flag.Bool("strict", false, "treat warnings as failures")
+	baseline = flag.String("baseline", "", "path to a previous JSON report or a git ref to check GUID stability against")
+)
+
 func main() {
-	success := true
+	flag.Parse()
+
+	report := Report{}
+	parseFailed := false
 	err := filepath.Walk("./feed", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && (strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".rss")) {
-			fmt.Printf("Verifying %s...\n", path)
-			if err = verifyRSS(path); err != nil {
-				fmt.Printf("Validation failed: %v\n", err)
-				success = false
-			}
+		if info.IsDir() || !(strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".rss")) {
+			return nil
+		}
+		report.FilesChecked++
+		report.Files = append(report.Files, path)
+		issues, records, err := verifyRSS(path)
+		if err != nil {
+			parseFailed = true
+			report.Issues = append(report.Issues, ValidationIssue{
+				File:     path,
+				Rule:     "parse",
+				Severity: SeverityError,
+				Message:  err.Error(),
+			})
+			return nil
 		}
+		report.Issues = append(report.Issues, issues...)
+		report.Records = append(report.Records, records...)
 		return nil
 	})
 	if err != nil {
 		fmt.Printf("Error walking the path: %v\n", err)
-		os.Exit(1)
+		os.Exit(3)
 	}
-	if !success {
-		os.Exit(1)
+
+	report.Issues = append(report.Issues, crossFileGUIDIssues(report.Records)...)
+
+	if *baseline != "" {
+		baselineRecords, err := loadBaseline(*baseline)
+		if err != nil {
+			fmt.Printf("Error loading baseline %q: %v\n", *baseline, err)
+			os.Exit(3)
+		}
+		report.Issues = append(report.Issues, baselineGUIDIssues(report.Records, baselineRecords)...)
 	}
+
+	output, err := renderReport(report, *format)
+	if err != nil {
+		fmt.Printf("Error rendering report: %v\n", err)
+		os.Exit(3)
+	}
+	fmt.Println(output)
+
+	os.Exit(exitCode(report, parseFailed, *strict))
 }
 
-func verifyRSS(filePath string) error {
+// exitCode maps a finished report to a process exit status: 3 means a feed
+// file couldn't be read or parsed at all, 2 means at least one rule error,
+// 1 means only warnings but -strict was set, and 0 means the run is clean.
+func exitCode(report Report, parseFailed, strict bool) int {
+	if parseFailed {
+		return 3
+	}
+	if report.errorCount() > 0 {
+		return 2
+	}
+	if strict && report.warningCount() > 0 {
+		return 1
+	}
+	return 0
+}
+
+// verifyRSS sniffs the root element of the feed file and dispatches to the
+// validator for that format (RSS 2.0, Atom, or a podcast feed), since all
+// of them are commonly served with a .xml extension. The returned error is
+// reserved for I/O or XML parse failures; rule violations come back as
+// ValidationIssues so callers can report them without aborting the run. The
+// returned GUIDRecords feed the cross-file uniqueness and baseline checks,
+// which need every item's GUID/link/pubDate after the whole walk completes.
+func verifyRSS(filePath string) ([]ValidationIssue, []GUIDRecord, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("error reading file: %v", err)
+		return nil, nil, fmt.Errorf("error reading file: %v", err)
 	}
 
-	var rss RSS
-	err = xml.Unmarshal(data, &rss)
+	root, err := sniffRootElement(data)
 	if err != nil {
-		return fmt.Errorf("invalid XML in %s: %v", filePath, err)
+		return nil, nil, fmt.Errorf("invalid XML: %v", err)
 	}
 
-	if err = rss.Validate(); err != nil {
-		return fmt.Errorf("error in %s: %v", filePath, err)
+	var issues []ValidationIssue
+	var records []GUIDRecord
+	switch root.Name.Local {
+	case "feed":
+		var feed Atom
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, nil, fmt.Errorf("invalid XML: %v", err)
+		}
+		issues = feed.Validate()
+	case "rss":
+		if isPodcastFeed(root) {
+			var podcast PodcastRSS
+			if err := xml.Unmarshal(data, &podcast); err != nil {
+				return nil, nil, fmt.Errorf("invalid XML: %v", err)
+			}
+			issues = podcast.Validate()
+			records = guidRecords(podcast.asRSS())
+		} else {
+			var rss RSS
+			if err := xml.Unmarshal(data, &rss); err != nil {
+				return nil, nil, fmt.Errorf("invalid XML: %v", err)
+			}
+			issues = rss.Validate()
+			records = guidRecords(rss)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unrecognized feed root element <%s>", root.Name.Local)
 	}
 
-	fmt.Printf("RSS file verification passed for %s!\nf", filePath)
-	return nil
+	for i := range issues {
+		issues[i].File = filePath
+	}
+	for i := range records {
+		records[i].File = filePath
+	}
+	return issues, records, nil
+}
+
+// sniffRootElement returns the document's root start element - name and
+// attributes - without fully unmarshalling it, so callers can pick the
+// right schema and detect namespace-gated extensions like podcast feeds.
+func sniffRootElement(data []byte) (xml.StartElement, error) {
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// itunesNamespace is the DTD URI podcast feeds declare on the <rss> root,
+// e.g. xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd".
+const itunesNamespace = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+
+// isPodcastFeed reports whether the root element declares the iTunes
+// podcast namespace, in which case the feed should be validated with the
+// stricter podcast rules instead of plain RSS 2.0 ones.
+func isPodcastFeed(root xml.StartElement) bool {
+	for _, attr := range root.Attr {
+		if attr.Name.Space == "xmlns" && attr.Value == itunesNamespace {
+			return true
+		}
+	}
+	return false
 }
 
 type RSS struct {
@@ -60,95 +182,264 @@ type RSS struct {
 }
 
 type Channel struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	Items       []Item `xml:"item"`
+	Title          string     `xml:"title"`
+	Link           string     `xml:"link"`
+	Description    string     `xml:"description"`
+	Language       string     `xml:"language"`
+	PubDate        string     `xml:"pubDate"`
+	LastBuildDate  string     `xml:"lastBuildDate"`
+	Generator      string     `xml:"generator"`
+	ManagingEditor string     `xml:"managingEditor"`
+	WebMaster      string     `xml:"webMaster"`
+	Docs           string     `xml:"docs"`
+	TTL            string     `xml:"ttl"`
+	Image          *Image     `xml:"image"`
+	Categories     []Category `xml:"category"`
+	Items          []Item     `xml:"item"`
+}
+
+type Image struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type Category struct {
+	Domain string `xml:"domain,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type Enclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type Source struct {
+	URL   string `xml:"url,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GUID models <guid isPermaLink="true|false">value</guid>. Per the RSS 2.0
+// spec, isPermaLink defaults to "true" when the attribute is omitted.
+type GUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// IsPermaLink reports whether this GUID is meant to resolve as an absolute
+// URL, honoring the spec's true-by-default behavior.
+func (g GUID) IsPermalink() bool {
+	return g.IsPermaLink != "false"
 }
 
 type Item struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	GUID        string `xml:"guid"`
+	Title       string      `xml:"title"`
+	Link        string      `xml:"link"`
+	Description string      `xml:"description"`
+	Author      string      `xml:"author"`
+	Categories  []Category  `xml:"category"`
+	Comments    string      `xml:"comments"`
+	Enclosures  []Enclosure `xml:"enclosure"`
+	GUID        GUID        `xml:"guid"`
+	PubDate     string      `xml:"pubDate"`
+	Source      *Source     `xml:"source"`
 }
 
-func (r *RSS) Validate() error {
-	rules := []func() error{
-		r.validatePubDate,
+func (r *RSS) Validate() []ValidationIssue {
+	rules := []func() []ValidationIssue{
+		r.validateRequiredChannelFields,
 		r.validateItemGUIDs,
+		r.validateGUIDPermalinks,
 		r.validateItemDates,
 		r.validatePubDateUpdated,
 	}
-	var errs []error
+	var issues []ValidationIssue
 	for _, rule := range rules {
-		if err := rule(); err != nil {
-			errs = append(errs, err)
-		}
+		issues = append(issues, rule()...)
+	}
+	for i := range issues {
+		issues[i].Feed = r.Channel.Title
 	}
-	return errors.Join(errs...)
+	return issues
 }
 
-func (r *RSS) validatePubDate() error {
-	err := validateRSSDate(r.Channel.PubDate)
-	if err != nil {
-		return fmt.Errorf("channel pub date: %v", err)
+// validateRequiredChannelFields checks the channel elements that RSS 2.0
+// mandates: title, link, and description.
+func (r *RSS) validateRequiredChannelFields() []ValidationIssue {
+	var missing []string
+	if r.Channel.Title == "" {
+		missing = append(missing, "title")
 	}
-	return nil
+	if r.Channel.Link == "" {
+		missing = append(missing, "link")
+	}
+	if r.Channel.Description == "" {
+		missing = append(missing, "description")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []ValidationIssue{errorIssue("required-channel-fields",
+		fmt.Sprintf("channel missing required element(s): %s", strings.Join(missing, ", ")))}
 }
 
-func (r *RSS) validateItemDates() error {
+func (r *RSS) validateItemDates() []ValidationIssue {
+	var issues []ValidationIssue
 	for _, item := range r.Channel.Items {
-		err := validateRSSDate(item.PubDate)
-		if err != nil {
-			return fmt.Errorf("item '%s' pub date: %v", item.Title, err)
+		if err := validateRSSDate(item.PubDate); err != nil {
+			issues = append(issues, ValidationIssue{
+				Item:     item.Title,
+				Rule:     "item-pub-date",
+				Severity: SeverityError,
+				Message:  err.Error(),
+			})
 		}
 	}
-	return nil
+	return issues
 }
 
-func (r *RSS) validateItemGUIDs() error {
+func (r *RSS) validateItemGUIDs() []ValidationIssue {
+	var issues []ValidationIssue
 	guids := make(map[string]bool)
 	for _, item := range r.Channel.Items {
-		if guids[item.GUID] {
-			return fmt.Errorf("duplicate GUID found: %s", item.GUID)
+		if item.GUID.Value == "" {
+			continue
 		}
-		guids[item.GUID] = true
+		if guids[item.GUID.Value] {
+			issues = append(issues, ValidationIssue{
+				Item:     item.Title,
+				Rule:     "duplicate-guid",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("duplicate GUID found: %s", item.GUID.Value),
+			})
+		}
+		guids[item.GUID.Value] = true
 	}
-	return nil
+	return issues
+}
+
+// validateGUIDPermalinks checks that any GUID acting as a permalink - either
+// explicitly isPermaLink="true" or defaulting to it - parses as an absolute
+// URL, since readers are allowed to dereference it directly.
+func (r *RSS) validateGUIDPermalinks() []ValidationIssue {
+	var issues []ValidationIssue
+	for _, item := range r.Channel.Items {
+		if item.GUID.Value == "" || !item.GUID.IsPermalink() {
+			continue
+		}
+		u, err := url.Parse(item.GUID.Value)
+		if err != nil || !u.IsAbs() {
+			issues = append(issues, ValidationIssue{
+				Item:     item.Title,
+				Rule:     "guid-permalink",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("guid '%s' is marked as a permalink but is not an absolute URL", item.GUID.Value),
+			})
+		}
+	}
+	return issues
 }
 
-func (r *RSS) validatePubDateUpdated() error {
+// validatePubDateUpdated checks the invariant that the channel's pubDate (or
+// lastBuildDate, if pubDate is absent) is never older than its newest item,
+// and warns - without failing validation - when items aren't listed newest
+// first, since readers tolerate this but it usually indicates a feed bug.
+func (r *RSS) validatePubDateUpdated() []ValidationIssue {
 	if len(r.Channel.Items) == 0 {
 		return nil
 	}
 
-	chanDate, err := time.Parse(time.RFC1123Z, r.Channel.PubDate)
+	channelDateStr := r.Channel.PubDate
+	if channelDateStr == "" {
+		channelDateStr = r.Channel.LastBuildDate
+	}
+	chanDate, err := parseFeedDate(channelDateStr)
 	if err != nil {
-		return fmt.Errorf("invalid date format in channel '%s'", r.Channel.PubDate)
+		return []ValidationIssue{errorIssue("pub-date-order", fmt.Sprintf("invalid date format in channel '%s'", channelDateStr))}
 	}
 
-	latestItem := r.Channel.Items[0]
-	itemDate, err := time.Parse(time.RFC1123Z, latestItem.PubDate)
-	if err != nil {
-		return fmt.Errorf("invalid date format in item '%s'", latestItem.PubDate)
+	var maxItemDate, prevItemDate time.Time
+	outOfOrder := false
+	for i, item := range r.Channel.Items {
+		itemDate, err := parseFeedDate(item.PubDate)
+		if err != nil {
+			return []ValidationIssue{errorIssue("pub-date-order", fmt.Sprintf("invalid date format in item '%s'", item.Title))}
+		}
+		if itemDate.After(maxItemDate) {
+			maxItemDate = itemDate
+		}
+		if i > 0 && itemDate.After(prevItemDate) {
+			outOfOrder = true
+		}
+		prevItemDate = itemDate
 	}
 
-	if !chanDate.Equal(itemDate) {
-		return fmt.Errorf("publication dates of channel and item do not match")
+	if chanDate.Before(maxItemDate) {
+		return []ValidationIssue{errorIssue("pub-date-order", fmt.Sprintf("channel pub date '%s' is older than its newest item", channelDateStr))}
+	}
+
+	if outOfOrder {
+		return []ValidationIssue{warningIssue("pub-date-order", "items are not listed in chronological order")}
 	}
 
 	return nil
 }
 
 func validateRSSDate(str string) error {
-	t, err := time.Parse(time.RFC1123Z, str)
-	if err != nil {
-		return fmt.Errorf("invalid date format in %s", str)
+	_, err := parseFeedDate(str)
+	return err
+}
+
+// wordpressDateLayout matches the format WordPress and many podcast hosts
+// emit, which differs from RFC1123Z only in that the day-of-month isn't
+// zero-padded (e.g. "Mon, 2 Jan 2006" instead of "Mon, 02 Jan 2006").
+const wordpressDateLayout = "Mon, 2 Jan 2006 15:04:05 -0700"
+
+// feedDateLayout pairs a layout with whether it encodes a weekday that
+// time.Parse will happily accept even if it doesn't match the parsed date.
+type feedDateLayout struct {
+	layout       string
+	checkWeekday bool
+}
+
+// feedDateLayouts is tried in priority order: strict RFC1123 variants first,
+// then the looser formats real-world publishers (WordPress, podcast hosts,
+// Atom generators) actually emit.
+var feedDateLayouts = []feedDateLayout{
+	{time.RFC1123Z, true},
+	{time.RFC1123, true},
+	{time.RFC822Z, false},
+	{time.RFC822, false},
+	{wordpressDateLayout, true},
+	{time.RFC3339, false},
+}
+
+// parseFeedDate parses a pubDate/updated value against the list of formats
+// real feeds use in practice, rejecting a match if the weekday text (when
+// present) doesn't agree with the parsed date. A weekday mismatch means a
+// layout otherwise fit the string, so that error is reported in preference
+// to a later layout's unrelated hard parse failure.
+func parseFeedDate(str string) (time.Time, error) {
+	var hardErr, weekdayErr error
+	for _, l := range feedDateLayouts {
+		t, err := time.Parse(l.layout, str)
+		if err != nil {
+			if hardErr == nil {
+				hardErr = err
+			}
+			continue
+		}
+		if l.checkWeekday && t.Format(l.layout) != str {
+			if weekdayErr == nil {
+				weekdayErr = fmt.Errorf("day of week is not correct: expected %s, got %s", t.Format(l.layout), str)
+			}
+			continue
+		}
+		return t, nil
 	}
-	// Check that day of week was set correctly, as it is ignored by time.Parse.
-	if str != t.Format(time.RFC1123Z) {
-		return fmt.Errorf("day of week is not correct: expected %s, got %s", t.Format(time.RFC1123Z), str)
+	if weekdayErr != nil {
+		return time.Time{}, fmt.Errorf("invalid date format in '%s': %v", str, weekdayErr)
 	}
-	return nil
+	return time.Time{}, fmt.Errorf("invalid date format in '%s': %v", str, hardErr)
 }
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFeedDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"rfc1123z", "Mon, 02 Jan 2006 15:04:05 -0700", false},
+		{"rfc1123", "Mon, 02 Jan 2006 15:04:05 MST", false},
+		{"rfc822z", "02 Jan 06 15:04 -0700", false},
+		{"rfc822", "02 Jan 06 15:04 MST", false},
+		{"wordpress unpadded day", "Mon, 2 Jan 2006 15:04:05 -0700", false},
+		{"rfc3339 atom", "2006-01-02T15:04:05Z", false},
+		{"wrong weekday", "Tue, 02 Jan 2006 15:04:05 -0700", true},
+		{"garbage", "not a date", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseFeedDate(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("parseFeedDate(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFeedDateReportsWeekdayMismatch(t *testing.T) {
+	// 2021-09-06 is a Monday; this string wrongly calls it a Tuesday. Every
+	// layout in the list either parses it with the wrong weekday or fails
+	// outright, so the weekday error should win over a generic parse error.
+	_, err := parseFeedDate("Tue, 06 Sep 2021 09:00:00 +0000")
+	if err == nil {
+		t.Fatal("expected an error for a mismatched weekday")
+	}
+	const want = "day of week is not correct"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("parseFeedDate error = %q, want it to contain %q", err.Error(), want)
+	}
+}
+
+func TestRSSValidateRequiredChannelFields(t *testing.T) {
+	rss := RSS{Channel: Channel{Description: "only a description"}}
+
+	issues := rss.Validate()
+
+	var found *ValidationIssue
+	for i := range issues {
+		if issues[i].Rule == "required-channel-fields" {
+			found = &issues[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Validate() = %+v, want a required-channel-fields issue", issues)
+	}
+	for _, want := range []string{"title", "link"} {
+		if !strings.Contains(found.Message, want) {
+			t.Errorf("required-channel-fields message = %q, want it to mention %q", found.Message, want)
+		}
+	}
+	if strings.Contains(found.Message, "description") {
+		t.Errorf("required-channel-fields message = %q, should not flag description (it was set)", found.Message)
+	}
+}
+
+func TestAtomValidateRequiredFeedFields(t *testing.T) {
+	feed := Atom{
+		Title:   "only a title",
+		Entries: []AtomEntry{{Title: "entry with no id or updated"}},
+	}
+
+	issues := feed.Validate()
+
+	var feedIssue, entryIssue *ValidationIssue
+	for i := range issues {
+		switch issues[i].Rule {
+		case "required-feed-fields":
+			feedIssue = &issues[i]
+		case "required-entry-fields":
+			entryIssue = &issues[i]
+		}
+	}
+	if feedIssue == nil {
+		t.Fatalf("Validate() = %+v, want a required-feed-fields issue", issues)
+	}
+	if !strings.Contains(feedIssue.Message, "id") || !strings.Contains(feedIssue.Message, "updated") {
+		t.Errorf("required-feed-fields message = %q, want it to mention id and updated", feedIssue.Message)
+	}
+	if entryIssue == nil {
+		t.Fatalf("Validate() = %+v, want a required-entry-fields issue", issues)
+	}
+	if !strings.Contains(entryIssue.Message, "id") || !strings.Contains(entryIssue.Message, "updated") {
+		t.Errorf("required-entry-fields message = %q, want it to mention id and updated", entryIssue.Message)
+	}
+}
+
+func TestValidateGUIDPermalinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		guid      GUID
+		wantIssue bool
+	}{
+		{"absolute URL, default permalink", GUID{Value: "https://example.com/1"}, false},
+		{"relative value, default permalink", GUID{Value: "not-a-url"}, true},
+		{"relative value, explicitly a permalink", GUID{IsPermaLink: "true", Value: "not-a-url"}, true},
+		{"relative value, not a permalink", GUID{IsPermaLink: "false", Value: "not-a-url"}, false},
+		{"empty guid", GUID{}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rss := RSS{Channel: Channel{Items: []Item{{Title: "item", GUID: tc.guid}}}}
+			issues := rss.validateGUIDPermalinks()
+			if (len(issues) > 0) != tc.wantIssue {
+				t.Errorf("validateGUIDPermalinks() = %+v, wantIssue %v", issues, tc.wantIssue)
+			}
+		})
+	}
+}
+
+func writeTempFeed(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing temp feed: %v", err)
+	}
+	return path
+}
+
+func TestVerifyRSSDispatchesByRootElement(t *testing.T) {
+	const rssFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>T</title><link>https://example.com</link><description>D</description></channel></rss>`
+	const podcastFeed = `<?xml version="1.0"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel><title>T</title><link>https://example.com</link><description>D</description></channel></rss>`
+	const atomFeed = `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><id>urn:1</id><title>T</title><updated>2021-09-06T09:00:00Z</updated></feed>`
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"rss", rssFeed},
+		{"podcast", podcastFeed},
+		{"atom", atomFeed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeTempFeed(t, tc.body)
+			issues, _, err := verifyRSS(path)
+			if err != nil {
+				t.Fatalf("verifyRSS(%s) error = %v", tc.name, err)
+			}
+			if len(issues) != 0 {
+				t.Errorf("verifyRSS(%s) = %+v, want no issues for a feed with every required field", tc.name, issues)
+			}
+		})
+	}
+}
+
+func TestVerifyRSSUnrecognizedRoot(t *testing.T) {
+	path := writeTempFeed(t, `<?xml version="1.0"?><opml version="2.0"><body></body></opml>`)
+
+	_, _, err := verifyRSS(path)
+
+	if err == nil {
+		t.Fatal("verifyRSS() with an <opml> root should return an error")
+	}
+	if !strings.Contains(err.Error(), "unrecognized feed root element") {
+		t.Errorf("verifyRSS() error = %q, want it to mention the unrecognized root element", err.Error())
+	}
+}